@@ -3,22 +3,37 @@ package version
 import (
 	"fmt"
 
+	"github.com/rhd-gitops-example/gitops-cli/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 // RecommendedCommandName is the recommended environment command name.
 const RecommendedCommandName = "version"
 
+// Version is kept for compatibility with existing -ldflags invocations that
+// set it directly; NewCmd reads build metadata from pkg/version instead.
 var Version string
 
 // NewCmd creates a new environment command
 func NewCmd(name, fullName string) *cobra.Command {
-	return &cobra.Command{
+	var output string
+
+	cmd := &cobra.Command{
 		Use:   name,
 		Short: "Get version",
 		Long:  "Get command version",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("gitops version %s\n", Version)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if Version != "" {
+				version.Version = Version
+			}
+			out, err := version.Format(version.Get(), output)
+			if err != nil {
+				return err
+			}
+			fmt.Print(out)
+			return nil
 		},
 	}
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "output format: text, json or yaml")
+	return cmd
 }
@@ -0,0 +1,87 @@
+// Package artifact provides a pluggable abstraction for writing generated
+// pipeline resources to a destination other than the local filesystem, e.g.
+// an S3 or GCS bucket, so that CI jobs can push manifests directly to object
+// storage without an intermediate checkout.
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// URL is a parsed artifact destination, e.g. "s3://bucket/prefix/file.yaml"
+// or "gs://bucket/prefix/file.yaml".
+type URL struct {
+	Scheme string
+	Bucket string
+	Object string
+}
+
+// String returns the canonical string form of the URL.
+func (u *URL) String() string {
+	return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Bucket, strings.TrimPrefix(u.Object, "/"))
+}
+
+// Service is implemented by each concrete object-storage backend (and by the
+// default local filesystem backend) that BuildResources can write generated
+// manifests through.
+type Service interface {
+	// ParseURL parses a raw destination string into its component parts.
+	ParseURL(raw string) (*URL, error)
+
+	// WriteArtifact writes content to the location identified by url.
+	WriteArtifact(ctx context.Context, url *URL, content []byte) error
+
+	// ReadArtifact reads the content stored at the location identified by url.
+	ReadArtifact(ctx context.Context, url *URL) ([]byte, error)
+
+	// DeleteArtifact removes the content stored at the location identified by url.
+	DeleteArtifact(ctx context.Context, url *URL) error
+}
+
+// registry maps a URL scheme (e.g. "s3", "gs") to the Service implementation
+// that handles it.
+var registry = map[string]Service{}
+
+// Register adds a Service implementation under the given scheme, overwriting
+// any existing registration. It is typically called from an init() function
+// in the package that implements a specific backend.
+func Register(scheme string, svc Service) {
+	registry[scheme] = svc
+}
+
+// unsupportedSchemeError is returned by Get when no Service is registered for
+// a scheme.
+func unsupportedSchemeError(scheme string) error {
+	return fmt.Errorf("unsupported artifact storage scheme %q", scheme)
+}
+
+// Get looks up the Service registered for scheme.
+func Get(scheme string) (Service, error) {
+	svc, ok := registry[scheme]
+	if !ok {
+		return nil, unsupportedSchemeError(scheme)
+	}
+	return svc, nil
+}
+
+// IsRemoteURL reports whether raw looks like a scheme-qualified artifact URL
+// (e.g. "s3://..." or "gs://...") as opposed to a bare local filesystem path.
+func IsRemoteURL(raw string) bool {
+	idx := strings.Index(raw, "://")
+	return idx > 0
+}
+
+// ParseURL parses raw using the Service registered for its scheme.
+func ParseURL(raw string) (*URL, error) {
+	idx := strings.Index(raw, "://")
+	if idx <= 0 {
+		return nil, fmt.Errorf("not an artifact URL: %q", raw)
+	}
+	svc, err := Get(raw[:idx])
+	if err != nil {
+		return nil, err
+	}
+	return svc.ParseURL(raw)
+}
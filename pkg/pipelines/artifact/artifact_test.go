@@ -0,0 +1,130 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeService is an in-memory stand-in for a real object-storage backend,
+// used in place of fake_gcs_server/minio for fast, dependency-free tests.
+type fakeService struct {
+	scheme string
+	mu     sync.Mutex
+	data   map[string][]byte
+}
+
+func newFakeService(scheme string) *fakeService {
+	return &fakeService{scheme: scheme, data: map[string][]byte{}}
+}
+
+func (f *fakeService) ParseURL(raw string) (*URL, error) {
+	rest := strings.TrimPrefix(raw, f.scheme+"://")
+	parts := strings.SplitN(rest, "/", 2)
+	if rest == raw || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%s:// URL must be of the form %s://bucket/object, got %q", f.scheme, f.scheme, raw)
+	}
+	return &URL{Scheme: f.scheme, Bucket: parts[0], Object: parts[1]}, nil
+}
+
+func (f *fakeService) key(url *URL) string {
+	return url.Bucket + "/" + url.Object
+}
+
+func (f *fakeService) WriteArtifact(ctx context.Context, url *URL, content []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[f.key(url)] = content
+	return nil
+}
+
+func (f *fakeService) ReadArtifact(ctx context.Context, url *URL) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.data[f.key(url)]
+	if !ok {
+		return nil, fmt.Errorf("no such object: %s", f.key(url))
+	}
+	return b, nil
+}
+
+func (f *fakeService) DeleteArtifact(ctx context.Context, url *URL) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, f.key(url))
+	return nil
+}
+
+func TestParseURL(t *testing.T) {
+	Register("fake", newFakeService("fake"))
+
+	tests := []struct {
+		raw     string
+		want    *URL
+		wantErr bool
+	}{
+		{"fake://my-bucket/prefix/file.yaml", &URL{Scheme: "fake", Bucket: "my-bucket", Object: "prefix/file.yaml"}, false},
+		{"fake://my-bucket", nil, true},
+		{"/tmp/local/path", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(rt *testing.T) {
+			got, err := ParseURL(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					rt.Fatalf("ParseURL(%q) did not return an error", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				rt.Fatalf("ParseURL(%q) returned unexpected error: %v", tt.raw, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				rt.Fatalf("ParseURL(%q) mismatch (-want +got):\n%s", tt.raw, diff)
+			}
+		})
+	}
+}
+
+func TestFakeServiceRoundTrip(t *testing.T) {
+	svc := newFakeService("fake")
+	Register("fake", svc)
+	ctx := context.Background()
+
+	url, err := ParseURL("fake://bucket/manifests/deployment.yaml")
+	if err != nil {
+		t.Fatalf("ParseURL() returned error: %v", err)
+	}
+
+	want := []byte("apiVersion: v1\nkind: Deployment\n")
+	if err := svc.WriteArtifact(ctx, url, want); err != nil {
+		t.Fatalf("WriteArtifact() returned error: %v", err)
+	}
+
+	got, err := svc.ReadArtifact(ctx, url)
+	if err != nil {
+		t.Fatalf("ReadArtifact() returned error: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		rt := t
+		rt.Fatalf("round-trip content mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := svc.DeleteArtifact(ctx, url); err != nil {
+		t.Fatalf("DeleteArtifact() returned error: %v", err)
+	}
+	if _, err := svc.ReadArtifact(ctx, url); err == nil {
+		t.Fatalf("ReadArtifact() after delete did not return an error")
+	}
+}
+
+func TestGetUnsupportedScheme(t *testing.T) {
+	if _, err := Get("ftp"); err == nil {
+		t.Fatalf("Get(%q) did not return an error", "ftp")
+	}
+}
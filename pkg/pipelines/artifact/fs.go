@@ -0,0 +1,57 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+func init() {
+	Register("file", NewFilesystemService(afero.NewOsFs()))
+}
+
+// filesystemService is the Service for "file://" URLs. BuildResources does
+// not use it for bare local paths (OutputPath values that are not
+// scheme-qualified URLs); those are written by yaml.WriteResources.
+type filesystemService struct {
+	fs afero.Fs
+}
+
+// NewFilesystemService returns a Service that reads and writes artifacts
+// through the given afero.Fs, treating URL.Object as a local path.
+func NewFilesystemService(fs afero.Fs) Service {
+	return &filesystemService{fs: fs}
+}
+
+func (s *filesystemService) ParseURL(raw string) (*URL, error) {
+	const scheme = "file://"
+	object := raw
+	if strings.HasPrefix(raw, scheme) {
+		object = strings.TrimPrefix(raw, scheme)
+	}
+	return &URL{Scheme: "file", Object: object}, nil
+}
+
+func (s *filesystemService) WriteArtifact(ctx context.Context, url *URL, content []byte) error {
+	if err := afero.WriteFile(s.fs, url.Object, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", url.Object, err)
+	}
+	return nil
+}
+
+func (s *filesystemService) ReadArtifact(ctx context.Context, url *URL) ([]byte, error) {
+	b, err := afero.ReadFile(s.fs, url.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url.Object, err)
+	}
+	return b, nil
+}
+
+func (s *filesystemService) DeleteArtifact(ctx context.Context, url *URL) error {
+	if err := s.fs.Remove(url.Object); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", url.Object, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package artifact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+)
+
+func TestFilesystemServiceRoundTrip(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	svc := NewFilesystemService(fs)
+	ctx := context.Background()
+
+	url, err := svc.ParseURL("file:///output/manifests/deployment.yaml")
+	if err != nil {
+		t.Fatalf("ParseURL() returned error: %v", err)
+	}
+
+	want := []byte("apiVersion: v1\nkind: Deployment\n")
+	if err := svc.WriteArtifact(ctx, url, want); err != nil {
+		t.Fatalf("WriteArtifact() returned error: %v", err)
+	}
+
+	got, err := svc.ReadArtifact(ctx, url)
+	if err != nil {
+		t.Fatalf("ReadArtifact() returned error: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Fatalf("round-trip content mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := svc.DeleteArtifact(ctx, url); err != nil {
+		t.Fatalf("DeleteArtifact() returned error: %v", err)
+	}
+	if _, err := svc.ReadArtifact(ctx, url); err == nil {
+		t.Fatalf("ReadArtifact() after delete did not return an error")
+	}
+}
+
+func TestFilesystemServiceParseURLStripsScheme(t *testing.T) {
+	svc := NewFilesystemService(afero.NewMemMapFs())
+
+	url, err := svc.ParseURL("file:///output/deployment.yaml")
+	if err != nil {
+		t.Fatalf("ParseURL() returned error: %v", err)
+	}
+	if url.Object != "/output/deployment.yaml" {
+		t.Fatalf("ParseURL().Object = %q, want %q", url.Object, "/output/deployment.yaml")
+	}
+
+	bare, err := svc.ParseURL("/output/deployment.yaml")
+	if err != nil {
+		t.Fatalf("ParseURL() returned error for a bare path: %v", err)
+	}
+	if bare.Object != "/output/deployment.yaml" {
+		t.Fatalf("ParseURL().Object = %q, want %q", bare.Object, "/output/deployment.yaml")
+	}
+}
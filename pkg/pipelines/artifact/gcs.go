@@ -0,0 +1,96 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+func init() {
+	Register("gs", &gcsService{})
+}
+
+// gcsService is a Service backed by Google Cloud Storage.
+type gcsService struct {
+	client   *storage.Client
+	endpoint string
+}
+
+// NewGCSService returns a Service backed by the GCS-compatible endpoint at
+// baseURL (e.g. a fake-gcs-server instance) instead of Google's own
+// endpoints, connecting without authentication the way such emulators
+// expect. It exists so both real alternate endpoints and tests can point
+// WriteArtifact/ReadArtifact/DeleteArtifact somewhere other than GCS itself.
+func NewGCSService(baseURL string) Service {
+	return &gcsService{endpoint: baseURL}
+}
+
+func (s *gcsService) ParseURL(raw string) (*URL, error) {
+	const scheme = "gs://"
+	if !strings.HasPrefix(raw, scheme) {
+		return nil, fmt.Errorf("not a gs:// URL: %q", raw)
+	}
+	rest := strings.TrimPrefix(raw, scheme)
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("gs:// URL must be of the form gs://bucket/object, got %q", raw)
+	}
+	return &URL{Scheme: "gs", Bucket: parts[0], Object: parts[1]}, nil
+}
+
+func (s *gcsService) getClient(ctx context.Context) (*storage.Client, error) {
+	if s.client != nil {
+		return s.client, nil
+	}
+	var opts []option.ClientOption
+	if s.endpoint != "" {
+		opts = append(opts, option.WithEndpoint(s.endpoint), option.WithoutAuthentication())
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	s.client = client
+	return client, nil
+}
+
+func (s *gcsService) WriteArtifact(ctx context.Context, url *URL, content []byte) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	w := client.Bucket(url.Bucket).Object(url.Object).NewWriter(ctx)
+	if _, err := w.Write(content); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write gs://%s/%s: %w", url.Bucket, url.Object, err)
+	}
+	return w.Close()
+}
+
+func (s *gcsService) ReadArtifact(ctx context.Context, url *URL) ([]byte, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := client.Bucket(url.Bucket).Object(url.Object).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gs://%s/%s: %w", url.Bucket, url.Object, err)
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func (s *gcsService) DeleteArtifact(ctx context.Context, url *URL) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := client.Bucket(url.Bucket).Object(url.Object).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete gs://%s/%s: %w", url.Bucket, url.Object, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func init() {
+	Register("s3", &s3Service{})
+}
+
+// s3Service is a Service backed by Amazon S3 (or an S3-compatible endpoint
+// such as a minio-style stub used in tests).
+type s3Service struct {
+	client   *s3.Client
+	endpoint string
+}
+
+// NewS3Service returns a Service backed by the S3-compatible endpoint at
+// baseURL instead of AWS's own endpoints, using path-style bucket addressing
+// as minio and other self-hosted S3-compatible servers require. It exists
+// so both real minio deployments and tests can point WriteArtifact/
+// ReadArtifact/DeleteArtifact at a non-AWS endpoint.
+func NewS3Service(baseURL string) Service {
+	return &s3Service{endpoint: baseURL}
+}
+
+func (s *s3Service) ParseURL(raw string) (*URL, error) {
+	const scheme = "s3://"
+	if !strings.HasPrefix(raw, scheme) {
+		return nil, fmt.Errorf("not an s3:// URL: %q", raw)
+	}
+	rest := strings.TrimPrefix(raw, scheme)
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("s3:// URL must be of the form s3://bucket/object, got %q", raw)
+	}
+	return &URL{Scheme: "s3", Bucket: parts[0], Object: parts[1]}, nil
+}
+
+func (s *s3Service) getClient(ctx context.Context) (*s3.Client, error) {
+	if s.client != nil {
+		return s.client, nil
+	}
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	s.client = s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if s.endpoint != "" {
+			o.BaseEndpoint = aws.String(s.endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return s.client, nil
+}
+
+func (s *s3Service) WriteArtifact(ctx context.Context, url *URL, content []byte) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(url.Bucket),
+		Key:    aws.String(url.Object),
+		Body:   bytes.NewReader(content),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write s3://%s/%s: %w", url.Bucket, url.Object, err)
+	}
+	return nil
+}
+
+func (s *s3Service) ReadArtifact(ctx context.Context, url *URL) ([]byte, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(url.Bucket),
+		Key:    aws.String(url.Object),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read s3://%s/%s: %w", url.Bucket, url.Object, err)
+	}
+	defer out.Body.Close()
+	return ioutil.ReadAll(out.Body)
+}
+
+func (s *s3Service) DeleteArtifact(ctx context.Context, url *URL) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(url.Bucket),
+		Key:    aws.String(url.Object),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete s3://%s/%s: %w", url.Bucket, url.Object, err)
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package pipelines
 
 import (
+	"context"
+
 	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/argocd"
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/artifact"
 	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/config"
 	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/environments"
 	res "github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/resources"
@@ -22,14 +25,71 @@ func BuildResources(o *BuildParameters, appFs afero.Fs) error {
 	if err != nil {
 		return err
 	}
+
+	// Validate expands any generators: blocks into concrete
+	// Applications/Services (see Manifest.Expand) before checking names and
+	// SCM consistency, so environments.Build below only ever sees the
+	// flattened manifest, whether its apps/services were declared statically
+	// or produced by a generator.
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
 	resources, err := buildResources(appFs, o, m)
 	if err != nil {
 		return err
 	}
+
+	// OutputPath may be a bare local path (the default, handled by
+	// yaml.WriteResources) or a scheme-qualified artifact URL such as
+	// "s3://bucket/prefix" or "gs://bucket/prefix", in which case the
+	// resources are streamed straight to object storage instead.
+	if artifact.IsRemoteURL(o.OutputPath) {
+		return writeResourcesToArtifactStore(appFs, o.OutputPath, resources)
+	}
+
 	_, err = yaml.WriteResources(appFs, o.OutputPath, resources)
 	return err
 }
 
+// writeResourcesToArtifactStore streams each generated resource file through
+// the artifact.Service selected by the OutputPath's URL scheme, so CI jobs
+// can push manifests directly to a bucket without an intermediate checkout.
+// A "file://" OutputPath is the one scheme handled locally rather than
+// through the artifact registry, so it goes through appFs - the same
+// filesystem BuildResources was given - instead of always hitting the real
+// OS filesystem the registry's own "file" Service is registered against.
+func writeResourcesToArtifactStore(appFs afero.Fs, outputPath string, resources res.Resources) error {
+	ctx := context.Background()
+
+	for name, content := range resources {
+		url, err := artifact.ParseURL(outputPath + "/" + name)
+		if err != nil {
+			return err
+		}
+
+		var svc artifact.Service
+		if url.Scheme == "file" {
+			svc = artifact.NewFilesystemService(appFs)
+		} else {
+			svc, err = artifact.Get(url.Scheme)
+			if err != nil {
+				return err
+			}
+		}
+
+		body, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+
+		if err := svc.WriteArtifact(ctx, url, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func buildResources(fs afero.Fs, o *BuildParameters, m *config.Manifest) (res.Resources, error) {
 	resources := res.Resources{}
 
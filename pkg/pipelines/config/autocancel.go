@@ -0,0 +1,23 @@
+package config
+
+import "fmt"
+
+// AutoCancelNoWebhookSecretError is returned by Validate when a service sets
+// `autoCancel: true` but does not configure a webhook secret (applications
+// have no autoCancel setting of their own): the
+// EventListener the CLI generates for it has no way to authenticate the
+// push/pull_request events that drive cancellation.
+func AutoCancelNoWebhookSecretError(path []string) error {
+	return fmt.Errorf("%s: autoCancel requires a webhook secret to be configured", joinPath(path))
+}
+
+func joinPath(path []string) string {
+	out := ""
+	for i, p := range path {
+		if i > 0 {
+			out += "."
+		}
+		out += p
+	}
+	return out
+}
@@ -0,0 +1,11 @@
+package config
+
+import "testing"
+
+func TestAutoCancelNoWebhookSecretError(t *testing.T) {
+	got := AutoCancelNoWebhookSecretError([]string{"environments", "dev", "apps", "app-1", "services", "svc-1"})
+	want := "environments.dev.apps.app-1.services.svc-1: autoCancel requires a webhook secret to be configured"
+	if got.Error() != want {
+		t.Fatalf("AutoCancelNoWebhookSecretError() = %q, want %q", got.Error(), want)
+	}
+}
@@ -0,0 +1,12 @@
+package config
+
+import "github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/generators"
+
+// GeneratorConfig is embedded in an Environment or Application to declare a
+// `generators:` block. The flattened output of each entry's generator is
+// substituted into Template to produce the concrete apps/services that
+// environments.Build operates on, mirroring Argo CD's ApplicationSet.
+type GeneratorConfig struct {
+	Generators []generators.Spec `yaml:"generators,omitempty"`
+	Template   string            `yaml:"template,omitempty"`
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/generators"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest is the root of the GitOps CLI configuration file.
+type Manifest struct {
+	GitOpsURL    string         `yaml:"gitops_url"`
+	ArgoCD       *ArgoCDConfig  `yaml:"argocd,omitempty"`
+	Config       []ConfigEntry  `yaml:"config,omitempty"`
+	Environments []*Environment `yaml:"environments"`
+
+	// expanded guards against Expand appending generated apps/services more
+	// than once, since Validate calls Expand and Validate may itself be
+	// called more than once against the same Manifest.
+	expanded bool
+}
+
+// ConfigEntry is a piece of shared configuration (e.g. a CI/CD namespace)
+// identified by Key, whose Name must be a valid DNS-1035 label and must not
+// collide with any Environment's Name.
+type ConfigEntry struct {
+	Key  string `yaml:"key"`
+	Name string `yaml:"name,omitempty"`
+}
+
+// ArgoCDConfig configures the Argo CD instance the generated Application
+// resources are registered against.
+type ArgoCDConfig struct {
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// Environment is a deployment target (e.g. "dev", "staging") containing one
+// or more Applications, either listed directly or expanded from Generators.
+type Environment struct {
+	Name            string         `yaml:"name"`
+	Apps            []*Application `yaml:"apps,omitempty"`
+	GeneratorConfig `yaml:",inline"`
+}
+
+// Application groups the Services that make up a single deployable unit,
+// either listed directly or expanded from Generators. An Application is
+// either a list of Services managed by this manifest, or a pointer at a
+// ConfigRepo managed elsewhere - never both.
+type Application struct {
+	Name            string      `yaml:"name"`
+	Services        []*Service  `yaml:"services,omitempty"`
+	ConfigRepo      *Repository `yaml:"config_repo,omitempty"`
+	GeneratorConfig `yaml:",inline"`
+}
+
+// Repository points at an Application's config repository when it is
+// managed outside this manifest's generated Services.
+type Repository struct {
+	URL  string `yaml:"url,omitempty"`
+	Path string `yaml:"path,omitempty"`
+}
+
+// Service is a single component with a source repository, optionally wired
+// to a webhook, opted into autoCancel, and/or linked to its Tekton
+// integration pipeline.
+type Service struct {
+	Name       string     `yaml:"name"`
+	SourceURL  string     `yaml:"source_url,omitempty"`
+	Webhook    *Webhook   `yaml:"webhook,omitempty"`
+	AutoCancel bool       `yaml:"autoCancel,omitempty"`
+	Pipelines  *Pipelines `yaml:"pipelines,omitempty"`
+}
+
+// Webhook configures the secret used to authenticate incoming SCM webhook
+// events for a Service.
+type Webhook struct {
+	Secret string `yaml:"secret,omitempty"`
+}
+
+// Pipelines names the Tekton resources used to build and deploy a Service.
+type Pipelines struct {
+	Integration *Integration `yaml:"integration,omitempty"`
+}
+
+// Integration names the TriggerBinding/TriggerTemplate pair a Service's
+// integration pipeline runs from. Template is optional: a Service with a
+// Binding but no Template only contributes parameters to another trigger.
+type Integration struct {
+	Binding  string `yaml:"binding,omitempty"`
+	Template string `yaml:"template,omitempty"`
+}
+
+// GetArgoCDConfig returns the manifest's Argo CD configuration, or nil if
+// the manifest does not use Argo CD.
+func (m *Manifest) GetArgoCDConfig() *ArgoCDConfig {
+	return m.ArgoCD
+}
+
+// ParseFile reads and unmarshals the manifest at filename from fs.
+func ParseFile(fs afero.Fs, filename string) (*Manifest, error) {
+	body, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %q: %w", filename, err)
+	}
+	m := &Manifest{}
+	if err := yaml.Unmarshal(body, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %q: %w", filename, err)
+	}
+	return m, nil
+}
+
+// LoadManifest reads and unmarshals the manifest found under
+// pipelinesFolderPath, i.e. "<pipelinesFolderPath>/pipelines.yaml".
+func LoadManifest(fs afero.Fs, pipelinesFolderPath string) (*Manifest, error) {
+	return ParseFile(fs, pipelinesFolderPath+"/pipelines.yaml")
+}
+
+// Expand flattens every generators: block in the manifest into concrete
+// Applications/Services, appending them to the relevant Environment's Apps
+// or Application's Services alongside any statically-declared entries. It
+// is called by Validate (so generated names/URLs are checked like static
+// ones), which BuildResources runs before environments.Build (so the
+// environments package only ever sees the already-flattened slices),
+// mirroring how Argo CD's ApplicationSet
+// controller expands its generators before reconciling Applications.
+func (m *Manifest) Expand() error {
+	if m.expanded {
+		return nil
+	}
+	m.expanded = true
+
+	for _, env := range m.Environments {
+		path := []string{"environments", env.Name}
+
+		generated, err := expandGeneratorConfig(&env.GeneratorConfig, path)
+		if err != nil {
+			return err
+		}
+		for _, doc := range generated {
+			app := &Application{}
+			if err := yaml.Unmarshal([]byte(doc), app); err != nil {
+				return fmt.Errorf("%s: generated app does not parse: %w", strings.Join(path, "."), err)
+			}
+			env.Apps = append(env.Apps, app)
+		}
+
+		for _, app := range env.Apps {
+			appPath := append(append([]string{}, path...), "apps", app.Name)
+
+			generated, err := expandGeneratorConfig(&app.GeneratorConfig, appPath)
+			if err != nil {
+				return err
+			}
+			for _, doc := range generated {
+				svc := &Service{}
+				if err := yaml.Unmarshal([]byte(doc), svc); err != nil {
+					return fmt.Errorf("%s: generated service does not parse: %w", strings.Join(appPath, "."), err)
+				}
+				app.Services = append(app.Services, svc)
+			}
+		}
+	}
+	return nil
+}
+
+// expandGeneratorConfig evaluates cfg.Generators (if any) and substitutes
+// each result's parameters into cfg.Template, returning one resolved YAML
+// document per generated entry. It fails if any "{{placeholder}}" is left
+// unresolved.
+func expandGeneratorConfig(cfg *GeneratorConfig, path []string) ([]string, error) {
+	if len(cfg.Generators) == 0 {
+		return nil, nil
+	}
+
+	var docs []string
+	for i := range cfg.Generators {
+		params, err := generators.Generate(context.Background(), &cfg.Generators[i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s.generators[%d]: %w", strings.Join(path, "."), i, err)
+		}
+		for _, p := range params {
+			doc := generators.Substitute(cfg.Template, p)
+			if strings.Contains(doc, "{{") {
+				return nil, fmt.Errorf("%s: generator parameters do not resolve all template placeholders in %q", strings.Join(path, "."), doc)
+			}
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/generators"
+)
+
+func TestExpandGeneratorsIntoServices(t *testing.T) {
+	m := &Manifest{
+		GitOpsURL: "https://github.com/myproject/gitops.git",
+		Environments: []*Environment{
+			{
+				Name: "dev",
+				Apps: []*Application{
+					{
+						Name: "app-1",
+						GeneratorConfig: GeneratorConfig{
+							Template: `name: "{{name}}"
+source_url: "{{url}}"`,
+							Generators: []generators.Spec{
+								{List: &generators.ListGenerator{Elements: []map[string]string{
+									{"name": "svc-a", "url": "https://github.com/myproject/svc-a.git"},
+									{"name": "svc-b", "url": "https://github.com/myproject/svc-b.git"},
+								}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := m.Expand(); err != nil {
+		t.Fatalf("Expand() returned error: %v", err)
+	}
+
+	app := m.Environments[0].Apps[0]
+	if len(app.Services) != 2 {
+		t.Fatalf("got %d services after Expand(), want 2", len(app.Services))
+	}
+	if app.Services[0].Name != "svc-a" || app.Services[1].Name != "svc-b" {
+		t.Fatalf("unexpected generated service names: %+v, %+v", app.Services[0], app.Services[1])
+	}
+	if app.Services[0].SourceURL != "https://github.com/myproject/svc-a.git" {
+		t.Fatalf("unexpected generated source_url: %q", app.Services[0].SourceURL)
+	}
+}
+
+func TestValidateChecksGeneratedNames(t *testing.T) {
+	m := &Manifest{
+		GitOpsURL: "https://github.com/myproject/gitops.git",
+		Environments: []*Environment{
+			{
+				Name: "dev",
+				Apps: []*Application{
+					{
+						Name: "app-1",
+						GeneratorConfig: GeneratorConfig{
+							Template: `name: "{{name}}"`,
+							Generators: []generators.Spec{
+								{List: &generators.ListGenerator{Elements: []map[string]string{
+									{"name": "Not_Valid!"},
+								}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := m.Validate()
+	if err == nil {
+		t.Fatalf("Validate() did not return an error for an invalid generated service name")
+	}
+}
+
+func TestValidateFailsOnUnresolvedPlaceholder(t *testing.T) {
+	m := &Manifest{
+		GitOpsURL: "https://github.com/myproject/gitops.git",
+		Environments: []*Environment{
+			{
+				Name: "dev",
+				Apps: []*Application{
+					{
+						Name: "app-1",
+						GeneratorConfig: GeneratorConfig{
+							Template: `name: "{{name}}"
+source_url: "{{url}}"`,
+							Generators: []generators.Spec{
+								{List: &generators.ListGenerator{Elements: []map[string]string{
+									{"name": "svc-a"},
+								}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := m.Validate(); err == nil {
+		t.Fatalf("Validate() did not return an error for an unresolved template placeholder")
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import "github.com/rhd-gitops-example/gitops-cli/pkg/scm"
+
+// gitTypesMatch reports whether gitOpsURL and serviceURL belong to the same
+// scm.Provider. It replaces the old github/gitlab-only string comparison
+// behind inconsistentGitTypeError, so Validate can flag a mismatch across
+// any of the providers scm.Detect knows about (including a self-hosted
+// Bitbucket Server or an Azure DevOps URL).
+func gitTypesMatch(gitOpsURL, serviceURL string) bool {
+	gitOpsProvider := scm.Detect(gitOpsURL)
+	serviceProvider := scm.Detect(serviceURL)
+	if gitOpsProvider == nil || serviceProvider == nil {
+		return true
+	}
+	return gitOpsProvider.Type() == serviceProvider.Type()
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestGitTypesMatch(t *testing.T) {
+	tests := []struct {
+		desc                  string
+		gitOpsURL, serviceURL string
+		want                  bool
+	}{
+		{"same provider, github", "https://github.com/org/gitops.git", "https://github.com/org/service.git", true},
+		{"different providers, github vs gitlab", "https://github.com/org/gitops.git", "https://gitlab.com/org/service.git", false},
+		{"different providers, bitbucket server vs azure devops", "https://bitbucket.example.com/scm/org/gitops.git", "https://dev.azure.com/org/project/_git/service", false},
+		{"same provider, bitbucket server", "https://bitbucket.example.com/scm/org/gitops.git", "https://bitbucket.example.com/scm/org/service.git", true},
+		{"unknown provider is not flagged", "https://example.com/org/gitops.git", "https://example.com/org/service.git", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(rt *testing.T) {
+			if got := gitTypesMatch(tt.gitOpsURL, tt.serviceURL); got != tt.want {
+				rt.Fatalf("gitTypesMatch(%q, %q) = %v, want %v", tt.gitOpsURL, tt.serviceURL, got, tt.want)
+			}
+		})
+	}
+}
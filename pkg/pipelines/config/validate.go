@@ -0,0 +1,264 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mkmik/multierror"
+	"github.com/rhd-gitops-example/gitops-cli/pkg/scm"
+	"knative.dev/pkg/apis"
+)
+
+// dns1035ErrMsg is the message used whenever a name (static or generated)
+// fails DNS-1035 validation.
+const dns1035ErrMsg = "a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')"
+
+// LongServiceNameError is the message used when a service name is too long
+// to be used as a Kubernetes resource name once the CLI's naming
+// conventions (prefixes/suffixes) are applied to it.
+const LongServiceNameError = "Service name must be less than 53 characters."
+
+// maxServiceNameLength is the length at which a Service.Name is flagged
+// with LongServiceNameError: names must be shorter than this, since the CLI
+// appends its own suffixes (e.g. "-ci-dryrun-from-pr") when turning a
+// Service.Name into a Kubernetes resource name.
+const maxServiceNameLength = 53
+
+var dns1035Pattern = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// Validate checks the manifest for consistency. It first expands any
+// generators: blocks (see Expand) so that generated apps/services are
+// checked exactly like statically-declared ones, then checks that: config
+// entries and environment/application/service names (static or generated)
+// are valid DNS-1035 labels and unique, environment names do not collide
+// with config names, services whose source repository uses a different
+// scm.Provider than the GitOps repository are flagged, source URLs are not
+// reused across services, applications declare exactly one of Services or
+// ConfigRepo, and services declare the fields autoCancel/webhooks/pipeline
+// integration require.
+func (m *Manifest) Validate() error {
+	var errs []error
+
+	if err := m.Expand(); err != nil {
+		errs = append(errs, err)
+	}
+
+	configNames := map[string]bool{}
+	for _, c := range m.Config {
+		if c.Name != "" && !dns1035Pattern.MatchString(c.Name) {
+			errs = append(errs, invalidNameError(c.Name, dns1035ErrMsg, []string{joinNonEmpty("config", c.Key)}))
+		}
+		configNames[c.Name] = true
+	}
+
+	gitOpsProvider := scm.Detect(m.GitOpsURL)
+
+	var sourceURLOrder []string
+	sourceURLPaths := map[string][]string{}
+
+	for _, env := range m.Environments {
+		envPath := []string{"environments", env.Name}
+
+		for _, app := range env.Apps {
+			appPath := append(append([]string{}, envPath...), "apps", app.Name)
+
+			errs = append(errs, validateApplication(app, appPath)...)
+
+			for _, svc := range app.Services {
+				svcPath := append(append([]string{}, appPath...), "services", svc.Name)
+
+				errs = append(errs, validateService(svc, svcPath)...)
+
+				if svc.SourceURL != "" && gitOpsProvider != nil && !gitTypesMatch(m.GitOpsURL, svc.SourceURL) {
+					errs = append(errs, inconsistentGitTypeError(gitOpsProvider.Type(), svc.SourceURL, []string{joinNonEmpty(svcPath...)}))
+				}
+
+				if svc.SourceURL != "" {
+					if _, ok := sourceURLPaths[svc.SourceURL]; !ok {
+						sourceURLOrder = append(sourceURLOrder, svc.SourceURL)
+					}
+					sourceURLPaths[svc.SourceURL] = append(sourceURLPaths[svc.SourceURL], joinNonEmpty(svcPath...))
+				}
+			}
+
+			if name := duplicateServiceName(app.Services); name != "" {
+				errs = append(errs, duplicateFieldsError([]string{name}, []string{joinNonEmpty(append(append([]string{}, appPath...), "services", name)...)}))
+			}
+
+			if !dns1035Pattern.MatchString(app.Name) {
+				errs = append(errs, invalidNameError(app.Name, dns1035ErrMsg, []string{joinNonEmpty(appPath...)}))
+			}
+		}
+
+		if name := duplicateApplicationName(env.Apps); name != "" {
+			errs = append(errs, duplicateFieldsError([]string{name}, []string{joinNonEmpty(append(append([]string{}, envPath...), "apps", name)...)}))
+		}
+
+		if !dns1035Pattern.MatchString(env.Name) {
+			errs = append(errs, invalidNameError(env.Name, dns1035ErrMsg, []string{joinNonEmpty(envPath...)}))
+		}
+
+		if configNames[env.Name] {
+			errs = append(errs, invalidEnvironment(env.Name, "Environment name cannot be the same as a config name.", []string{joinNonEmpty(envPath...)}))
+		}
+	}
+
+	if name := duplicateEnvironmentName(m.Environments); name != "" {
+		errs = append(errs, duplicateFieldsError([]string{name}, []string{joinNonEmpty("environments", name)}))
+	}
+
+	for _, url := range sourceURLOrder {
+		if paths := sourceURLPaths[url]; len(paths) > 1 {
+			errs = append(errs, duplicateSourceError(url, paths))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return multierror.Join(errs)
+}
+
+// validateApplication checks that app declares exactly one of Services or
+// ConfigRepo, and that a declared ConfigRepo carries both its fields.
+func validateApplication(app *Application, path []string) []error {
+	hasServices := len(app.Services) > 0
+	hasConfigRepo := app.ConfigRepo != nil
+
+	switch {
+	case !hasServices && !hasConfigRepo:
+		return []error{missingFieldsError([]string{"services", "config_repo"}, []string{joinNonEmpty(path...)})}
+	case hasServices && hasConfigRepo:
+		return []error{apis.ErrMultipleOneOf(joinNonEmpty(append(append([]string{}, path...), "services")...), joinNonEmpty(append(append([]string{}, path...), "config_repo")...))}
+	case hasConfigRepo:
+		var missing []string
+		if app.ConfigRepo.URL == "" {
+			missing = append(missing, "url")
+		}
+		if app.ConfigRepo.Path == "" {
+			missing = append(missing, "path")
+		}
+		if len(missing) > 0 {
+			return []error{missingFieldsError(missing, []string{joinNonEmpty(append(append([]string{}, path...), "config_repo")...)})}
+		}
+	}
+	return nil
+}
+
+// validateService checks a single Service's name, length, pipeline
+// integration binding, webhook, and autoCancel configuration.
+func validateService(svc *Service, path []string) []error {
+	var errs []error
+
+	if !dns1035Pattern.MatchString(svc.Name) {
+		errs = append(errs, invalidNameError(svc.Name, dns1035ErrMsg, []string{joinNonEmpty(path...)}))
+	} else if len(svc.Name) >= maxServiceNameLength {
+		errs = append(errs, invalidNameError(svc.Name, LongServiceNameError, []string{joinNonEmpty(path...)}))
+	}
+
+	if svc.Pipelines != nil && svc.Pipelines.Integration != nil {
+		binding := svc.Pipelines.Integration.Binding
+		if !dns1035Pattern.MatchString(binding) {
+			errs = append(errs, invalidNameError(binding, dns1035ErrMsg, []string{joinNonEmpty(append(append([]string{}, path...), "pipelines", "integration", "binding")...)}))
+		}
+	}
+
+	switch {
+	case svc.Webhook != nil && svc.Webhook.Secret == "":
+		errs = append(errs, missingFieldsError([]string{"secret"}, []string{joinNonEmpty(append(append([]string{}, path...), "webhook")...)}))
+	case svc.AutoCancel && svc.Webhook == nil:
+		errs = append(errs, AutoCancelNoWebhookSecretError(path))
+	}
+
+	if svc.Pipelines != nil && svc.Pipelines.Integration == nil {
+		errs = append(errs, missingFieldsError([]string{"integration"}, []string{joinNonEmpty(append(append([]string{}, path...), "pipelines")...)}))
+	}
+
+	return errs
+}
+
+// duplicateEnvironmentName returns the first Name that appears more than
+// once among envs, in order of its first occurrence, or "" if every name
+// is unique.
+func duplicateEnvironmentName(envs []*Environment) string {
+	counts := map[string]int{}
+	for _, env := range envs {
+		counts[env.Name]++
+	}
+	for _, env := range envs {
+		if env.Name != "" && counts[env.Name] > 1 {
+			return env.Name
+		}
+	}
+	return ""
+}
+
+// duplicateApplicationName returns the first Name that appears more than
+// once among apps, in order of its first occurrence, or "" if every name
+// is unique.
+func duplicateApplicationName(apps []*Application) string {
+	counts := map[string]int{}
+	for _, app := range apps {
+		counts[app.Name]++
+	}
+	for _, app := range apps {
+		if app.Name != "" && counts[app.Name] > 1 {
+			return app.Name
+		}
+	}
+	return ""
+}
+
+// duplicateServiceName returns the first Name that appears more than once
+// among services, in order of its first occurrence, or "" if every name
+// is unique.
+func duplicateServiceName(services []*Service) string {
+	counts := map[string]int{}
+	for _, svc := range services {
+		counts[svc.Name]++
+	}
+	for _, svc := range services {
+		if svc.Name != "" && counts[svc.Name] > 1 {
+			return svc.Name
+		}
+	}
+	return ""
+}
+
+// joinNonEmpty joins parts with ".", skipping any empty segments, so a
+// generated path never ends with a stray separator when the final segment
+// (e.g. a name that failed validation) is itself empty.
+func joinNonEmpty(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
+
+func inconsistentGitTypeError(gitType, url string, paths []string) error {
+	return fmt.Errorf("%s: service repo URL %q is not a %s URL like the GitOps repository", strings.Join(paths, ", "), url, gitType)
+}
+
+func invalidEnvironment(name, msg string, paths []string) error {
+	return fmt.Errorf("%s: environment %q is invalid: %s", strings.Join(paths, ", "), name, msg)
+}
+
+func invalidNameError(name, msg string, paths []string) error {
+	return fmt.Errorf("%s: %q is invalid: %s", strings.Join(paths, ", "), name, msg)
+}
+
+func missingFieldsError(fields, paths []string) error {
+	return fmt.Errorf("%s: missing fields %s", strings.Join(paths, ", "), strings.Join(fields, ", "))
+}
+
+func duplicateFieldsError(names, paths []string) error {
+	return fmt.Errorf("%s: duplicate name(s) %s", strings.Join(paths, ", "), strings.Join(names, ", "))
+}
+
+func duplicateSourceError(url string, paths []string) error {
+	return fmt.Errorf("%s: source URL %q is used by more than one service", strings.Join(paths, ", "), url)
+}
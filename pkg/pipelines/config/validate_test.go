@@ -30,6 +30,24 @@ func TestValidate(t *testing.T) {
 				},
 			),
 		},
+		{
+			"service repo URL must be the same Git type as the GitOps URL, self-hosted Bitbucket Server vs Azure DevOps",
+			"testdata/svc_git_type_mismatch_bitbucket_server.yaml",
+			multierror.Join(
+				[]error{
+					inconsistentGitTypeError("bitbucket-server", "https://dev.azure.com/myorg/myproject/_git/myservice", []string{"environments.test-dev.apps.bus.services.bus-svc"}),
+				},
+			),
+		},
+		{
+			"service repo URL must be the same Git type as the GitOps URL, Azure DevOps vs self-hosted Bitbucket Server",
+			"testdata/svc_git_type_mismatch_azure_devops.yaml",
+			multierror.Join(
+				[]error{
+					inconsistentGitTypeError("azure-devops", "https://bitbucket.example.com/scm/myproject/myservice.git", []string{"environments.test-dev.apps.bus.services.bus-svc"}),
+				},
+			),
+		},
 		{
 			"Environment Duplicate Name entry",
 			"testdata/environment_config_name.yaml",
@@ -0,0 +1,74 @@
+package pipelines
+
+import (
+	"fmt"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/config"
+	res "github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/resources"
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	"github.com/rhd-gitops-example/gitops-cli/pkg/scm"
+)
+
+// eventListenerName is the name given to the single EventListener generated
+// for each environment, fronting every one of its services' webhooks.
+const eventListenerName = "gitops-webhook-event-listener"
+
+// buildEventListenerResources builds the EventListener for every environment
+// in m that has at least one service, selecting each service's webhook
+// EventInterceptor and autoCancel CancelOverlays from the scm.Provider that
+// matches its own SourceURL - falling back to the GitOps repository's
+// provider for services that don't declare one - and generates the
+// autoCancel CancelTask/TriggerBinding/TriggerTemplate for any environment
+// that has a service with AutoCancel set.
+func buildEventListenerResources(gitOpsURL string, m *config.Manifest) (res.Resources, error) {
+	resources := res.Resources{}
+	gitOpsProvider := scm.Detect(gitOpsURL)
+
+	for _, env := range m.Environments {
+		var trigs []triggers.Trigger
+		needsCancelTask := false
+
+		for _, app := range env.Apps {
+			for _, svc := range app.Services {
+				provider := gitOpsProvider
+				repo := gitOpsURL
+				if svc.SourceURL != "" {
+					if p := scm.Detect(svc.SourceURL); p != nil {
+						provider = p
+					}
+					repo = svc.SourceURL
+				}
+				if provider == nil {
+					return nil, fmt.Errorf("environments.%s.apps.%s.services.%s: could not detect an SCM provider for %q", env.Name, app.Name, svc.Name, svc.SourceURL)
+				}
+
+				trig := triggers.Trigger{
+					Name:        svc.Name,
+					Interceptor: provider.EventInterceptor(),
+				}
+				if svc.AutoCancel {
+					overlays := provider.CancelOverlays()
+					trig.CancelOverlays = &overlays
+					trig.Repo = repo
+					needsCancelTask = true
+				}
+				trigs = append(trigs, trig)
+			}
+		}
+
+		if len(trigs) == 0 {
+			continue
+		}
+
+		el, extra := triggers.BuildEventListener(eventListenerName, env.Name, trigs)
+		resources[fmt.Sprintf("%s/event-listener.yaml", env.Name)] = el
+		for name, obj := range extra {
+			resources[fmt.Sprintf("%s/%s", env.Name, name)] = obj
+		}
+		if needsCancelTask {
+			resources[fmt.Sprintf("%s/cancel-task.yaml", env.Name)] = triggers.CancelTask(env.Name)
+		}
+	}
+
+	return resources, nil
+}
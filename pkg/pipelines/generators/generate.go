@@ -0,0 +1,40 @@
+package generators
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/valyala/fasttemplate"
+)
+
+// Generate dispatches to the Generator implementation matching whichever
+// field of spec is set.
+func Generate(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error) {
+	switch {
+	case spec.List != nil:
+		return (&listGenerator{}).GenerateParams(ctx, spec, repoClient)
+	case spec.Git != nil:
+		return (&gitGenerator{}).GenerateParams(ctx, spec, repoClient)
+	case spec.PullRequest != nil:
+		return (&pullRequestGenerator{}).GenerateParams(ctx, spec, repoClient)
+	case spec.Matrix != nil:
+		return (&matrixGenerator{}).GenerateParams(ctx, spec, repoClient)
+	case spec.Merge != nil:
+		return (&mergeGenerator{}).GenerateParams(ctx, spec, repoClient)
+	default:
+		return nil, fmt.Errorf("generator spec has no generator configured")
+	}
+}
+
+// Substitute replaces "{{key}}" placeholders in template with the values
+// from params. A placeholder with no entry in params is left as it is.
+func Substitute(template string, params map[string]string) string {
+	t := fasttemplate.New(template, "{{", "}}")
+	return t.ExecuteFuncString(func(w io.Writer, tag string) (int, error) {
+		if v, ok := params[tag]; ok {
+			return w.Write([]byte(v))
+		}
+		return w.Write([]byte("{{" + tag + "}}"))
+	})
+}
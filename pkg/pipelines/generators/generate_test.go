@@ -0,0 +1,113 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeRepoClient struct {
+	dirs  []string
+	files []string
+	prs   []PullRequest
+}
+
+func (f *fakeRepoClient) ListDirectories(ctx context.Context, repoURL, revision, path string) ([]string, error) {
+	return f.dirs, nil
+}
+
+func (f *fakeRepoClient) ListFiles(ctx context.Context, repoURL, revision, path string) ([]string, error) {
+	return f.files, nil
+}
+
+func (f *fakeRepoClient) ListOpenPullRequests(ctx context.Context, repoURL string) ([]PullRequest, error) {
+	return f.prs, nil
+}
+
+func TestGenerateList(t *testing.T) {
+	spec := &Spec{List: &ListGenerator{Elements: []map[string]string{
+		{"name": "dev"}, {"name": "staging"},
+	}}}
+
+	got, err := Generate(context.Background(), spec, &fakeRepoClient{})
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	want := []map[string]string{{"name": "dev"}, {"name": "staging"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Generate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateGitDirectories(t *testing.T) {
+	spec := &Spec{Git: &GitGenerator{RepoURL: "https://github.com/example/apps.git", Directory: "apps/*"}}
+	client := &fakeRepoClient{dirs: []string{"apps/foo", "apps/bar"}}
+
+	got, err := Generate(context.Background(), spec, client)
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	want := []map[string]string{
+		{"path": "apps/foo", "path.basename": "foo"},
+		{"path": "apps/bar", "path.basename": "bar"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Generate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateMatrix(t *testing.T) {
+	spec := &Spec{Matrix: &MatrixGenerator{Generators: []Spec{
+		{List: &ListGenerator{Elements: []map[string]string{{"env": "dev"}, {"env": "prod"}}}},
+		{List: &ListGenerator{Elements: []map[string]string{{"region": "us"}}}},
+	}}}
+
+	got, err := Generate(context.Background(), spec, &fakeRepoClient{})
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	want := []map[string]string{
+		{"env": "dev", "region": "us"},
+		{"env": "prod", "region": "us"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Generate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateMatrixRequiresTwoGenerators(t *testing.T) {
+	spec := &Spec{Matrix: &MatrixGenerator{Generators: []Spec{
+		{List: &ListGenerator{Elements: []map[string]string{{"env": "dev"}}}},
+	}}}
+
+	if _, err := Generate(context.Background(), spec, &fakeRepoClient{}); err == nil {
+		t.Fatalf("Generate() did not return an error for a single-generator matrix")
+	}
+}
+
+func TestGenerateMerge(t *testing.T) {
+	spec := &Spec{Merge: &MergeGenerator{
+		MergeKey: "name",
+		Generators: []Spec{
+			{List: &ListGenerator{Elements: []map[string]string{{"name": "foo", "replicas": "1"}}}},
+			{List: &ListGenerator{Elements: []map[string]string{{"name": "foo", "replicas": "3"}}}},
+		},
+	}}
+
+	got, err := Generate(context.Background(), spec, &fakeRepoClient{})
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	want := []map[string]string{{"name": "foo", "replicas": "3"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Generate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	got := Substitute("svc-{{name}}-{{region}}", map[string]string{"name": "foo", "region": "us"})
+	if want := "svc-foo-us"; got != want {
+		t.Fatalf("Substitute() = %q, want %q", got, want)
+	}
+}
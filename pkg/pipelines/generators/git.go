@@ -0,0 +1,44 @@
+package generators
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// gitGenerator implements Generator for Spec.Git: directories or files
+// discovered in a repo each become one parameter map, with "path" and
+// "path.basename" populated the same way Argo CD's git generator does.
+type gitGenerator struct{}
+
+func (g *gitGenerator) GenerateParams(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error) {
+	if spec.Git == nil {
+		return nil, nil
+	}
+	git := spec.Git
+
+	if git.FileGlob != "" {
+		files, err := repoClient.ListFiles(ctx, git.RepoURL, git.Revision, git.FileGlob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files for git generator %q: %w", git.RepoURL, err)
+		}
+		return paramsFor(files), nil
+	}
+
+	dirs, err := repoClient.ListDirectories(ctx, git.RepoURL, git.Revision, git.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directories for git generator %q: %w", git.RepoURL, err)
+	}
+	return paramsFor(dirs), nil
+}
+
+func paramsFor(paths []string) []map[string]string {
+	out := make([]map[string]string, 0, len(paths))
+	for _, p := range paths {
+		out = append(out, map[string]string{
+			"path":          p,
+			"path.basename": path.Base(p),
+		})
+	}
+	return out
+}
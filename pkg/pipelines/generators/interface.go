@@ -0,0 +1,41 @@
+// Package generators implements ApplicationSet-style generators that expand
+// a single environment/app definition in the manifest into a set of concrete
+// parameter maps at build time, mirroring Argo CD's ApplicationSet
+// generators.
+package generators
+
+import "context"
+
+// RepoClient is the subset of SCM functionality a generator needs in order
+// to enumerate directories, files or pull requests in a remote repository.
+// It is deliberately narrow so that tests can supply a fake.
+type RepoClient interface {
+	// ListDirectories returns the top-level directory names under path in
+	// the given repo/revision, used by the "git" directories generator.
+	ListDirectories(ctx context.Context, repoURL, revision, path string) ([]string, error)
+
+	// ListFiles returns the files matching a glob under path in the given
+	// repo/revision, used by the "git" files generator.
+	ListFiles(ctx context.Context, repoURL, revision, path string) ([]string, error)
+
+	// ListOpenPullRequests returns the open pull requests for repoURL, used
+	// by the "pullRequest" generator.
+	ListOpenPullRequests(ctx context.Context, repoURL string) ([]PullRequest, error)
+}
+
+// PullRequest is the minimal information the "pullRequest" generator needs
+// about an open pull request to build a preview environment for it.
+type PullRequest struct {
+	Number int
+	Branch string
+	SHA    string
+}
+
+// Generator produces a set of parameter maps that are substituted into the
+// service/app template the generator is attached to. Each returned map
+// becomes one generated entry.
+type Generator interface {
+	// GenerateParams evaluates spec and returns one parameter map per
+	// generated entry.
+	GenerateParams(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error)
+}
@@ -0,0 +1,16 @@
+package generators
+
+import "context"
+
+// listGenerator implements Generator for Spec.List: it simply returns the
+// inline elements the user configured, unchanged.
+type listGenerator struct{}
+
+func (g *listGenerator) GenerateParams(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error) {
+	if spec.List == nil {
+		return nil, nil
+	}
+	out := make([]map[string]string, len(spec.List.Elements))
+	copy(out, spec.List.Elements)
+	return out, nil
+}
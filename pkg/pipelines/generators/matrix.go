@@ -0,0 +1,48 @@
+package generators
+
+import (
+	"context"
+	"fmt"
+)
+
+// matrixGenerator implements Generator for Spec.Matrix: it produces the
+// cartesian product of exactly two child generators' results, merging each
+// pair of parameter maps.
+type matrixGenerator struct{}
+
+func (g *matrixGenerator) GenerateParams(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error) {
+	if spec.Matrix == nil {
+		return nil, nil
+	}
+	if len(spec.Matrix.Generators) != 2 {
+		return nil, fmt.Errorf("matrix generator requires exactly 2 child generators, got %d", len(spec.Matrix.Generators))
+	}
+
+	left, err := Generate(ctx, &spec.Matrix.Generators[0], repoClient)
+	if err != nil {
+		return nil, err
+	}
+	right, err := Generate(ctx, &spec.Matrix.Generators[1], repoClient)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]map[string]string, 0, len(left)*len(right))
+	for _, l := range left {
+		for _, r := range right {
+			out = append(out, mergeParams(l, r))
+		}
+	}
+	return out, nil
+}
+
+func mergeParams(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,39 @@
+package generators
+
+import "context"
+
+// mergeGenerator implements Generator for Spec.Merge: it joins the results
+// of its child generators by MergeKey, with later generators overriding
+// earlier ones' values for any parameter key they share.
+type mergeGenerator struct{}
+
+func (g *mergeGenerator) GenerateParams(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error) {
+	if spec.Merge == nil {
+		return nil, nil
+	}
+
+	byKey := map[string]map[string]string{}
+	var order []string
+
+	for _, child := range spec.Merge.Generators {
+		params, err := Generate(ctx, &child, repoClient)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range params {
+			key := p[spec.Merge.MergeKey]
+			if existing, ok := byKey[key]; ok {
+				byKey[key] = mergeParams(existing, p)
+				continue
+			}
+			byKey[key] = p
+			order = append(order, key)
+		}
+	}
+
+	out := make([]map[string]string, 0, len(order))
+	for _, key := range order {
+		out = append(out, byKey[key])
+	}
+	return out, nil
+}
@@ -0,0 +1,33 @@
+package generators
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// pullRequestGenerator implements Generator for Spec.PullRequest: every open
+// pull request in the repo becomes one parameter map describing an ephemeral
+// preview environment.
+type pullRequestGenerator struct{}
+
+func (g *pullRequestGenerator) GenerateParams(ctx context.Context, spec *Spec, repoClient RepoClient) ([]map[string]string, error) {
+	if spec.PullRequest == nil {
+		return nil, nil
+	}
+
+	prs, err := repoClient.ListOpenPullRequests(ctx, spec.PullRequest.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests for %q: %w", spec.PullRequest.RepoURL, err)
+	}
+
+	out := make([]map[string]string, 0, len(prs))
+	for _, pr := range prs {
+		out = append(out, map[string]string{
+			"number": strconv.Itoa(pr.Number),
+			"branch": pr.Branch,
+			"sha":    pr.SHA,
+		})
+	}
+	return out, nil
+}
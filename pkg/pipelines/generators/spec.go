@@ -0,0 +1,47 @@
+package generators
+
+// Spec is the schema for a single entry in a manifest `generators:` block.
+// Exactly one of List, Git, PullRequest, Matrix or Merge should be set; this
+// mirrors how Argo CD's ApplicationSetGenerator is a union of generator
+// kinds.
+type Spec struct {
+	List        *ListGenerator        `yaml:"list,omitempty"`
+	Git         *GitGenerator         `yaml:"git,omitempty"`
+	PullRequest *PullRequestGenerator `yaml:"pullRequest,omitempty"`
+	Matrix      *MatrixGenerator      `yaml:"matrix,omitempty"`
+	Merge       *MergeGenerator       `yaml:"merge,omitempty"`
+}
+
+// ListGenerator produces one entry per inline item, each item supplying the
+// template variables substituted into the generated service/app.
+type ListGenerator struct {
+	Elements []map[string]string `yaml:"elements"`
+}
+
+// GitGenerator produces one entry per directory (or file) found in a repo,
+// mirroring Argo CD's git directories/files generator.
+type GitGenerator struct {
+	RepoURL   string `yaml:"repoURL"`
+	Revision  string `yaml:"revision"`
+	Directory string `yaml:"directory,omitempty"`
+	FileGlob  string `yaml:"file,omitempty"`
+}
+
+// PullRequestGenerator produces one entry per open pull request in a repo,
+// used to expand ephemeral preview environments.
+type PullRequestGenerator struct {
+	RepoURL string `yaml:"repoURL"`
+}
+
+// MatrixGenerator produces the cartesian product of two child generators.
+type MatrixGenerator struct {
+	Generators []Spec `yaml:"generators"`
+}
+
+// MergeGenerator joins the output of two or more child generators by a
+// shared key, with later generators' values overriding earlier ones for any
+// key they have in common.
+type MergeGenerator struct {
+	MergeKey   string `yaml:"mergeKey"`
+	Generators []Spec `yaml:"generators"`
+}
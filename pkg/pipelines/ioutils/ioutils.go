@@ -0,0 +1,11 @@
+// Package ioutils provides small filesystem helpers shared across the
+// pipelines packages.
+package ioutils
+
+import "github.com/spf13/afero"
+
+// NewFilesystem returns the afero.Fs the CLI uses to read manifest and
+// testdata files from the real, on-disk filesystem.
+func NewFilesystem() afero.Fs {
+	return afero.NewOsFs()
+}
@@ -0,0 +1,17 @@
+// Package resources holds the generic container type the pipelines
+// sub-packages (environments, argocd, triggers) build up and that
+// yaml.WriteResources ultimately serializes to disk.
+package resources
+
+// Resources maps a generated resource's relative file path to the decoded
+// object that will be marshalled into it.
+type Resources map[string]interface{}
+
+// Merge adds every entry of src into dst, overwriting any existing entry
+// with the same path, and returns dst.
+func Merge(src, dst Resources) Resources {
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
@@ -0,0 +1,145 @@
+// Package triggers builds the Tekton Trigger resources (EventListeners,
+// TriggerBindings, TriggerTemplates and their supporting Tasks) that the
+// pipelines package wires into the generated manifest.
+package triggers
+
+import (
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BranchLabel is stamped onto every PipelineRun a trigger template
+	// creates, so the cancel task can find sibling runs for the same branch.
+	BranchLabel = "pipeline.gitops/branch"
+
+	// RepoLabel is stamped onto every PipelineRun a trigger template
+	// creates, alongside BranchLabel, to scope the cancel task's selector to
+	// a single repository.
+	RepoLabel = "pipeline.gitops/repo"
+
+	// CommitSHALabel is stamped onto every PipelineRun a trigger template
+	// creates, so the cancel task can tell the PipelineRun for the
+	// triggering commit apart from the superseded ones it should cancel.
+	CommitSHALabel = "pipeline.gitops/commit-sha"
+
+	// CancelTaskName is the name of the generated Task that lists and
+	// cancels superseded PipelineRuns.
+	CancelTaskName = "cancel-pipelinerun"
+)
+
+// CancelOverlays are the CEL filter and field expressions needed to derive
+// the commit SHA, branch and event source that cancelling superseded
+// PipelineRuns requires, evaluated against one provider's own webhook
+// payload shape. Each scm.Provider supplies its own CancelOverlays, since
+// GitHub, GitLab, Bitbucket, Azure DevOps and CodeCommit all disagree on
+// where these fields live in the payload.
+type CancelOverlays struct {
+	// Filter is the CEL filter expression recognising the push/PR events
+	// that should be considered for cancellation.
+	Filter string
+
+	// CommitSHA is the CEL expression resolving the triggering commit's SHA.
+	CommitSHA string
+
+	// Branch is the CEL expression resolving the triggering branch.
+	Branch string
+
+	// EventSource is the CEL expression resolving a human-readable name for
+	// the event that triggered the run (e.g. the provider's event-type
+	// header or field).
+	EventSource string
+}
+
+// LabelSelector returns the k8s label selector the cancel Task uses to find
+// the PipelineRuns that belong to the same branch/repo as the incoming
+// event, so they can be superseded by the new one. It does not filter on a
+// PipelineRun's status.
+func LabelSelector(branch, repo string) string {
+	return BranchLabel + "=" + branch + "," + RepoLabel + "=" + repo
+}
+
+// CancelInterceptorFor returns the CEL interceptor that filters events per
+// overlays.Filter and annotates incoming PipelineRuns with the commit-sha,
+// branch and event-source it extracts from the webhook payload, using the
+// expressions the caller's scm.Provider supplied for its own payload shape.
+func CancelInterceptorFor(overlays CancelOverlays) *triggersv1alpha1.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		CEL: &triggersv1alpha1.CELInterceptor{
+			Filter: overlays.Filter,
+			Overlays: []triggersv1alpha1.CELOverlay{
+				{Key: "commit-sha", Expression: overlays.CommitSHA},
+				{Key: "branch", Expression: overlays.Branch},
+				{Key: "event-source", Expression: overlays.EventSource},
+			},
+		},
+	}
+}
+
+// CancelTask returns the generated Task that lists PipelineRuns matching
+// LabelSelector(branch, repo) and patches every one of them - other than the
+// PipelineRun for commitSHA - to `spec.status: PipelineRunCancelled`. It is
+// added to the manifest alongside the EventListener/TriggerBinding whenever a
+// service has `autoCancel: true` set; applications have no autoCancel
+// setting of their own.
+func CancelTask(namespace string) *pipelinev1beta1.Task {
+	return &pipelinev1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CancelTaskName,
+			Namespace: namespace,
+		},
+		Spec: pipelinev1beta1.TaskSpec{
+			Params: []pipelinev1beta1.ParamSpec{
+				{Name: "commit-sha", Type: pipelinev1beta1.ParamTypeString},
+				{Name: "branch", Type: pipelinev1beta1.ParamTypeString},
+				{Name: "repo", Type: pipelinev1beta1.ParamTypeString},
+			},
+			Steps: []pipelinev1beta1.Step{
+				{
+					Container: corev1.Container{
+						Name:  "cancel",
+						Image: "bitnami/kubectl:latest",
+					},
+					Script: cancelScript,
+				},
+			},
+		},
+	}
+}
+
+// cancelScript is the shell script run by the cancel Task's single step: it
+// lists every PipelineRun matching LabelSelector(branch, repo), then patches
+// every one of them whose commit-sha label does not match the incoming
+// commit to spec.status: PipelineRunCancelled.
+const cancelScript = `#!/usr/bin/env bash
+set -euo pipefail
+
+selector="` + BranchLabel + `=$(params.branch),` + RepoLabel + `=$(params.repo)"
+for run in $(kubectl get pipelineruns -l "${selector}" -o jsonpath='{.items[*].metadata.name}'); do
+  sha=$(kubectl get pipelinerun "${run}" -o jsonpath="{.metadata.labels.` + commitSHALabelJSONPath + `}")
+  if [ "${sha}" != "$(params.commit-sha)" ]; then
+    kubectl patch pipelinerun "${run}" --type merge -p '{"spec":{"status":"PipelineRunCancelled"}}'
+  fi
+done
+`
+
+// commitSHALabelJSONPath is CommitSHALabel with its dots escaped the way
+// kubectl's jsonpath requires when a label key itself contains dots.
+const commitSHALabelJSONPath = `pipeline\.gitops/commit-sha`
+
+// WithBranchLabels returns the labels that must be stamped onto every
+// PipelineRun a trigger template creates, so a later event for the same
+// branch/repo can find it via LabelSelector and tell it apart from the
+// PipelineRun for the new commit via CommitSHALabel.
+func WithBranchLabels(existing map[string]string, branch, repo, commitSHA string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range existing {
+		labels[k] = v
+	}
+	labels[BranchLabel] = branch
+	labels[RepoLabel] = repo
+	labels[CommitSHALabel] = commitSHA
+	return labels
+}
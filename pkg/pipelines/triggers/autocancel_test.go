@@ -0,0 +1,76 @@
+package triggers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLabelSelector(t *testing.T) {
+	got := LabelSelector("main", "example/app")
+	want := "pipeline.gitops/branch=main,pipeline.gitops/repo=example/app"
+	if got != want {
+		t.Fatalf("LabelSelector() = %q, want %q", got, want)
+	}
+}
+
+func TestWithBranchLabels(t *testing.T) {
+	got := WithBranchLabels(map[string]string{"tekton.dev/pipeline": "build"}, "main", "example/app", "abc123")
+
+	want := map[string]string{
+		"tekton.dev/pipeline": "build",
+		BranchLabel:           "main",
+		RepoLabel:             "example/app",
+		CommitSHALabel:        "abc123",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("WithBranchLabels() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("WithBranchLabels()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestCancelInterceptorForUsesProviderOverlays(t *testing.T) {
+	overlays := CancelOverlays{
+		Filter:      "header.match('X-GitHub-Event', 'push') || header.match('X-GitHub-Event', 'pull_request')",
+		CommitSHA:   "has(body.pull_request) ? body.pull_request.head.sha : body.head_commit.id",
+		Branch:      "has(body.pull_request) ? body.pull_request.head.ref : body.ref.split('/')[2]",
+		EventSource: "header.get('X-GitHub-Event')[0]",
+	}
+
+	interceptor := CancelInterceptorFor(overlays)
+	if interceptor.CEL.Filter != overlays.Filter {
+		t.Fatalf("CancelInterceptorFor() filter = %q, want %q", interceptor.CEL.Filter, overlays.Filter)
+	}
+
+	want := map[string]string{
+		"commit-sha":   overlays.CommitSHA,
+		"branch":       overlays.Branch,
+		"event-source": overlays.EventSource,
+	}
+	for _, overlay := range interceptor.CEL.Overlays {
+		if want[overlay.Key] != overlay.Expression {
+			t.Fatalf("CancelInterceptorFor() overlay %q = %q, want %q", overlay.Key, overlay.Expression, want[overlay.Key])
+		}
+	}
+}
+
+func TestCancelTaskListsAndPatchesByLabel(t *testing.T) {
+	task := CancelTask("my-namespace")
+
+	if task.Namespace != "my-namespace" {
+		t.Fatalf("CancelTask().Namespace = %q, want %q", task.Namespace, "my-namespace")
+	}
+	if len(task.Spec.Steps) != 1 {
+		t.Fatalf("CancelTask() has %d steps, want 1", len(task.Spec.Steps))
+	}
+
+	script := task.Spec.Steps[0].Script
+	for _, want := range []string{"kubectl get pipelineruns", "kubectl patch pipelinerun", "PipelineRunCancelled", BranchLabel, RepoLabel} {
+		if !strings.Contains(script, want) {
+			t.Fatalf("CancelTask() step script does not contain %q:\n%s", want, script)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EventInterceptor is the Tekton Trigger interceptor type used throughout
+// this package and by the scm package's per-provider interceptors.
+type EventInterceptor = triggersv1alpha1.EventInterceptor
+
+const (
+	// CancelTriggerBindingName is the name of the TriggerBinding that
+	// extracts the commit-sha/branch CancelInterceptorFor adds as
+	// extensions, for every autoCancel trigger in an EventListener.
+	CancelTriggerBindingName = "cancel-pipelinerun-binding"
+
+	// CancelTriggerTemplateName is the name of the TriggerTemplate that
+	// starts the PipelineRun invoking CancelTask, for every autoCancel
+	// trigger in an EventListener.
+	CancelTriggerTemplateName = "cancel-pipelinerun-template"
+)
+
+// Trigger is one service's webhook wiring: the EventInterceptor that
+// verifies and parses its provider's payload, plus whether superseded
+// PipelineRuns for the same branch/PR should be cancelled.
+type Trigger struct {
+	// Name identifies the trigger within the EventListener; it is the
+	// service's name.
+	Name string
+
+	// Interceptor is the provider-specific EventInterceptor returned by
+	// scm.Provider.EventInterceptor() for the service's source repository.
+	Interceptor *EventInterceptor
+
+	// CancelOverlays, when set, mirrors config.Service.AutoCancel: the
+	// trigger chains CancelInterceptorFor(*CancelOverlays) after its
+	// provider interceptor, and is bound to
+	// CancelTriggerBinding/CancelTriggerTemplate so a superseding event
+	// cancels the PipelineRuns LabelSelector(branch, Repo) matches.
+	CancelOverlays *CancelOverlays
+
+	// Repo identifies the trigger's source repository (e.g.
+	// "myorg/myservice"), used to scope its cancel Task's LabelSelector to
+	// this trigger's own PipelineRuns.
+	Repo string
+}
+
+// BuildEventListener returns the EventListener that fronts every trigger in
+// triggers, one per service, in the given namespace (an environment name),
+// plus the TriggerBinding/TriggerTemplate referenced by any trigger with
+// CancelOverlays set, keyed by the file name they should be written to. A
+// trigger with CancelOverlays set chains
+// CancelInterceptorFor(*trig.CancelOverlays) after its provider interceptor,
+// so the branch/repo/commit-sha CancelTask needs are extracted from the same
+// payload that was just verified, and binds its trigger to
+// CancelTriggerBinding/CancelTriggerTemplate so that payload starts the
+// PipelineRun that runs CancelTask.
+func BuildEventListener(name, namespace string, triggers []Trigger) (*triggersv1alpha1.EventListener, map[string]interface{}) {
+	el := &triggersv1alpha1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	extra := map[string]interface{}{}
+
+	for _, trig := range triggers {
+		elTrigger := triggersv1alpha1.EventListenerTrigger{
+			Name:         trig.Name,
+			Interceptors: []*triggersv1alpha1.EventInterceptor{trig.Interceptor},
+		}
+
+		if trig.CancelOverlays != nil {
+			elTrigger.Interceptors = append(elTrigger.Interceptors, CancelInterceptorFor(*trig.CancelOverlays))
+			elTrigger.Bindings = []*triggersv1alpha1.EventListenerBinding{
+				{Name: CancelTriggerBindingName, Kind: triggersv1alpha1.NamespacedTriggerBindingKind},
+			}
+			elTrigger.Template = &triggersv1alpha1.EventListenerTemplate{Name: CancelTriggerTemplateName}
+
+			extra["cancel-trigger-binding.yaml"] = CancelTriggerBinding(namespace, trig.Repo)
+			extra["cancel-trigger-template.yaml"] = CancelTriggerTemplate(namespace)
+		}
+
+		el.Spec.Triggers = append(el.Spec.Triggers, elTrigger)
+	}
+
+	return el, extra
+}
+
+// CancelTriggerBinding returns the TriggerBinding that supplies
+// CancelTriggerTemplate's commit-sha and branch params from the extensions
+// CancelInterceptorFor adds to the event, and repo as the literal name of
+// the repository the cancel Task's LabelSelector should scope to.
+func CancelTriggerBinding(namespace, repo string) *triggersv1alpha1.TriggerBinding {
+	return &triggersv1alpha1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CancelTriggerBindingName,
+			Namespace: namespace,
+		},
+		Spec: triggersv1alpha1.TriggerBindingSpec{
+			Params: []triggersv1alpha1.Param{
+				{Name: "commit-sha", Value: "$(extensions.commit-sha)"},
+				{Name: "branch", Value: "$(extensions.branch)"},
+				{Name: "repo", Value: repo},
+			},
+		},
+	}
+}
+
+// CancelTriggerTemplate returns the TriggerTemplate that, for an event
+// carrying the params CancelTriggerBinding extracts, starts a PipelineRun
+// labelled via WithBranchLabels and whose single PipelineTask references
+// CancelTask by name, so that a superseding push/pull_request cancels the
+// PipelineRuns LabelSelector(branch, repo) matches.
+func CancelTriggerTemplate(namespace string) *triggersv1alpha1.TriggerTemplate {
+	ttParam := func(name, value string) pipelinev1beta1.Param {
+		return pipelinev1beta1.Param{Name: name, Value: pipelinev1beta1.ArrayOrString{Type: pipelinev1beta1.ParamTypeString, StringVal: value}}
+	}
+
+	pipelineRun := &pipelinev1beta1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "cancel-pipelinerun-",
+			Namespace:    namespace,
+			Labels:       WithBranchLabels(nil, "$(tt.params.branch)", "$(tt.params.repo)", "$(tt.params.commit-sha)"),
+		},
+		Spec: pipelinev1beta1.PipelineRunSpec{
+			Params: []pipelinev1beta1.Param{
+				ttParam("commit-sha", "$(tt.params.commit-sha)"),
+				ttParam("branch", "$(tt.params.branch)"),
+				ttParam("repo", "$(tt.params.repo)"),
+			},
+			PipelineSpec: &pipelinev1beta1.PipelineSpec{
+				Params: []pipelinev1beta1.ParamSpec{
+					{Name: "commit-sha", Type: pipelinev1beta1.ParamTypeString},
+					{Name: "branch", Type: pipelinev1beta1.ParamTypeString},
+					{Name: "repo", Type: pipelinev1beta1.ParamTypeString},
+				},
+				Tasks: []pipelinev1beta1.PipelineTask{
+					{
+						Name:    "cancel",
+						TaskRef: &pipelinev1beta1.TaskRef{Name: CancelTaskName},
+						Params: []pipelinev1beta1.Param{
+							ttParam("commit-sha", "$(params.commit-sha)"),
+							ttParam("branch", "$(params.branch)"),
+							ttParam("repo", "$(params.repo)"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(pipelineRun)
+	if err != nil {
+		// pipelineRun is built entirely from this function's own literals,
+		// so marshalling it can never actually fail.
+		panic(fmt.Sprintf("failed to marshal generated cancel PipelineRun: %v", err))
+	}
+
+	return &triggersv1alpha1.TriggerTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CancelTriggerTemplateName,
+			Namespace: namespace,
+		},
+		Spec: triggersv1alpha1.TriggerTemplateSpec{
+			Params: []triggersv1alpha1.ParamSpec{
+				{Name: "commit-sha"},
+				{Name: "branch"},
+				{Name: "repo"},
+			},
+			ResourceTemplates: []triggersv1alpha1.TriggerResourceTemplate{
+				{RawExtension: runtime.RawExtension{Raw: body}},
+			},
+		},
+	}
+}
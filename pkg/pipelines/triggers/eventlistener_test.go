@@ -0,0 +1,84 @@
+package triggers
+
+import (
+	"strings"
+	"testing"
+)
+
+var testCancelOverlays = CancelOverlays{
+	Filter:      "header.match('X-GitHub-Event', 'push')",
+	CommitSHA:   "body.head_commit.id",
+	Branch:      "body.ref.split('/')[2]",
+	EventSource: "header.get('X-GitHub-Event')[0]",
+}
+
+func TestBuildEventListenerChainsCancelInterceptorForAutoCancel(t *testing.T) {
+	el, extra := BuildEventListener("gitops-webhook-event-listener", "dev", []Trigger{
+		{Name: "svc-a", Interceptor: CancelInterceptorFor(testCancelOverlays)},
+		{Name: "svc-b", Interceptor: CancelInterceptorFor(testCancelOverlays), CancelOverlays: &testCancelOverlays, Repo: "example/svc-b"},
+	})
+
+	if el.Name != "gitops-webhook-event-listener" || el.Namespace != "dev" {
+		t.Fatalf("BuildEventListener() metadata = %+v", el.ObjectMeta)
+	}
+	if len(el.Spec.Triggers) != 2 {
+		t.Fatalf("got %d triggers, want 2", len(el.Spec.Triggers))
+	}
+
+	svcA, svcB := el.Spec.Triggers[0], el.Spec.Triggers[1]
+
+	if got := len(svcA.Interceptors); got != 1 {
+		t.Fatalf("svc-a trigger has %d interceptors, want 1 (no autoCancel)", got)
+	}
+	if svcA.Template != nil || len(svcA.Bindings) != 0 {
+		t.Fatalf("svc-a trigger has bindings/template %+v/%+v, want none (no autoCancel)", svcA.Bindings, svcA.Template)
+	}
+
+	if got := len(svcB.Interceptors); got != 2 {
+		t.Fatalf("svc-b trigger has %d interceptors, want 2 (provider + cancel)", got)
+	}
+	if svcB.Template == nil || svcB.Template.Name != CancelTriggerTemplateName {
+		t.Fatalf("svc-b trigger template = %+v, want %q", svcB.Template, CancelTriggerTemplateName)
+	}
+	if len(svcB.Bindings) != 1 || svcB.Bindings[0].Name != CancelTriggerBindingName {
+		t.Fatalf("svc-b trigger bindings = %+v, want [%q]", svcB.Bindings, CancelTriggerBindingName)
+	}
+
+	if _, ok := extra["cancel-trigger-binding.yaml"]; !ok {
+		t.Fatalf("BuildEventListener() extra resources missing cancel-trigger-binding.yaml: %+v", extra)
+	}
+	if _, ok := extra["cancel-trigger-template.yaml"]; !ok {
+		t.Fatalf("BuildEventListener() extra resources missing cancel-trigger-template.yaml: %+v", extra)
+	}
+}
+
+func TestCancelTriggerBindingUsesRepoLiteral(t *testing.T) {
+	binding := CancelTriggerBinding("dev", "example/svc-b")
+
+	if binding.Namespace != "dev" {
+		t.Fatalf("CancelTriggerBinding().Namespace = %q, want %q", binding.Namespace, "dev")
+	}
+	for _, p := range binding.Spec.Params {
+		if p.Name == "repo" && p.Value != "example/svc-b" {
+			t.Fatalf("CancelTriggerBinding() repo param = %q, want %q", p.Value, "example/svc-b")
+		}
+	}
+}
+
+func TestCancelTriggerTemplateReferencesCancelTask(t *testing.T) {
+	tt := CancelTriggerTemplate("dev")
+
+	if tt.Namespace != "dev" {
+		t.Fatalf("CancelTriggerTemplate().Namespace = %q, want %q", tt.Namespace, "dev")
+	}
+	if len(tt.Spec.ResourceTemplates) != 1 {
+		t.Fatalf("CancelTriggerTemplate() has %d resource templates, want 1", len(tt.Spec.ResourceTemplates))
+	}
+
+	raw := string(tt.Spec.ResourceTemplates[0].RawExtension.Raw)
+	for _, want := range []string{CancelTaskName, BranchLabel, RepoLabel, CommitSHALabel} {
+		if !strings.Contains(raw, want) {
+			t.Fatalf("CancelTriggerTemplate() resource template does not contain %q:\n%s", want, raw)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package scm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// awsCodeCommit matches AWS CodeCommit HTTPS clone URLs, e.g.
+// "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo".
+type awsCodeCommit struct{}
+
+func (a *awsCodeCommit) Type() string { return "aws-codecommit" }
+
+func (a *awsCodeCommit) Match(url string) bool {
+	return strings.Contains(url, "git-codecommit.") && strings.Contains(url, ".amazonaws.com")
+}
+
+// WebhookPath is the path CodeCommit events arrive on. CodeCommit has no
+// push webhook: events come from an EventBridge rule forwarded to this path
+// by a CloudWatch Events-to-HTTP bridge configured outside this CLI.
+func (a *awsCodeCommit) WebhookPath() string { return "/aws-codecommit" }
+
+func (a *awsCodeCommit) EventInterceptor() *triggers.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		CEL: &triggersv1alpha1.CELInterceptor{
+			Filter: `body.detail.event == 'referenceCreated' || body.detail.event == 'referenceUpdated'`,
+		},
+	}
+}
+
+// CancelOverlays derives the cancellation fields from an AWS CodeCommit
+// referenceCreated/referenceUpdated EventBridge payload. CodeCommit has no
+// pull-request event distinct from a reference update, so both the filter
+// and the fields are a single, unconditional expression.
+func (a *awsCodeCommit) CancelOverlays() triggers.CancelOverlays {
+	return triggers.CancelOverlays{
+		Filter:      `body.detail.event == 'referenceCreated' || body.detail.event == 'referenceUpdated'`,
+		CommitSHA:   `body.detail.commitId`,
+		Branch:      `body.detail.referenceName.split('/')[2]`,
+		EventSource: `body.detail.event`,
+	}
+}
+
+func (a *awsCodeCommit) PRPreviewRef(pr int) string {
+	return fmt.Sprintf("refs/heads/pr/%d", pr)
+}
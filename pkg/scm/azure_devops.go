@@ -0,0 +1,46 @@
+package scm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// azureDevOps matches Azure DevOps Services repo URLs of the form
+// "dev.azure.com/{org}/{project}/_git/{repo}".
+type azureDevOps struct{}
+
+func (a *azureDevOps) Type() string { return "azure-devops" }
+
+func (a *azureDevOps) Match(url string) bool {
+	return strings.Contains(url, "dev.azure.com") && strings.Contains(url, "/_git/")
+}
+
+func (a *azureDevOps) WebhookPath() string { return "/azure-devops" }
+
+func (a *azureDevOps) EventInterceptor() *triggers.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		CEL: &triggersv1alpha1.CELInterceptor{
+			Filter: `body.eventType == 'git.push' || body.eventType == 'git.pullrequest.created'`,
+		},
+	}
+}
+
+// CancelOverlays derives the cancellation fields from an Azure DevOps
+// git.push/git.pullrequest.created payload. The two event types keep the
+// commit/ref fields under different paths, so each expression branches on
+// body.eventType rather than assuming one payload shape.
+func (a *azureDevOps) CancelOverlays() triggers.CancelOverlays {
+	return triggers.CancelOverlays{
+		Filter:      `body.eventType == 'git.push' || body.eventType == 'git.pullrequest.created'`,
+		CommitSHA:   `body.eventType == 'git.pullrequest.created' ? body.resource.lastMergeSourceCommit.commitId : body.resource.refUpdates[0].newObjectId`,
+		Branch:      `body.eventType == 'git.pullrequest.created' ? body.resource.sourceRefName.split('/')[2] : body.resource.refUpdates[0].name.split('/')[2]`,
+		EventSource: `body.eventType`,
+	}
+}
+
+func (a *azureDevOps) PRPreviewRef(pr int) string {
+	return fmt.Sprintf("refs/pull/%d/merge", pr)
+}
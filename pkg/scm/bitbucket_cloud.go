@@ -0,0 +1,48 @@
+package scm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+type bitbucketCloud struct{}
+
+func (b *bitbucketCloud) Type() string { return "bitbucket-cloud" }
+
+func (b *bitbucketCloud) Match(url string) bool {
+	return strings.Contains(url, "bitbucket.org")
+}
+
+func (b *bitbucketCloud) WebhookPath() string { return "/bitbucket-cloud" }
+
+// EventInterceptor filters Bitbucket Cloud webhook events via the generic
+// CEL interceptor on the `X-Event-Key` header; Tekton Triggers has no
+// dedicated Bitbucket interceptor. The payload signature is not verified.
+func (b *bitbucketCloud) EventInterceptor() *triggers.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		CEL: &triggersv1alpha1.CELInterceptor{
+			Filter: `header.match('X-Event-Key', 'repo:push') || header.match('X-Event-Key', 'pullrequest:created')`,
+		},
+	}
+}
+
+// CancelOverlays derives the cancellation fields from a Bitbucket Cloud
+// repo:push/pullrequest:created payload. A pullrequest:created payload has
+// no body.push at all, so the branch/commit-sha expressions branch on
+// body.pullrequest's presence rather than evaluating body.push against it
+// directly.
+func (b *bitbucketCloud) CancelOverlays() triggers.CancelOverlays {
+	return triggers.CancelOverlays{
+		Filter:      `header.match('X-Event-Key', 'repo:push') || header.match('X-Event-Key', 'pullrequest:created')`,
+		CommitSHA:   `has(body.pullrequest) ? body.pullrequest.source.commit.hash : body.push.changes[0].new.target.hash`,
+		Branch:      `has(body.pullrequest) ? body.pullrequest.source.branch.name : body.push.changes[0].new.name`,
+		EventSource: `header.get('X-Event-Key')[0]`,
+	}
+}
+
+func (b *bitbucketCloud) PRPreviewRef(pr int) string {
+	return fmt.Sprintf("refs/pull-requests/%d/from", pr)
+}
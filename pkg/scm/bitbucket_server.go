@@ -0,0 +1,48 @@
+package scm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// bitbucketServer matches self-hosted Bitbucket Server/Data Center URLs,
+// which are not distinguishable by hostname alone and instead follow the
+// fixed "/scm/{project}/{repo}.git" path convention.
+type bitbucketServer struct{}
+
+func (b *bitbucketServer) Type() string { return "bitbucket-server" }
+
+func (b *bitbucketServer) Match(url string) bool {
+	return strings.Contains(url, "/scm/")
+}
+
+func (b *bitbucketServer) WebhookPath() string { return "/bitbucket-server" }
+
+func (b *bitbucketServer) EventInterceptor() *triggers.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		CEL: &triggersv1alpha1.CELInterceptor{
+			Filter: `header.match('X-Event-Key', 'repo:refs_changed') || header.match('X-Event-Key', 'pr:opened')`,
+		},
+	}
+}
+
+// CancelOverlays derives the cancellation fields from a Bitbucket Server
+// repo:refs_changed/pr:opened payload. A pr:opened payload has no
+// body.changes at all, so the branch/commit-sha expressions branch on
+// body.pullRequest's presence rather than evaluating body.changes against it
+// directly.
+func (b *bitbucketServer) CancelOverlays() triggers.CancelOverlays {
+	return triggers.CancelOverlays{
+		Filter:      `header.match('X-Event-Key', 'repo:refs_changed') || header.match('X-Event-Key', 'pr:opened')`,
+		CommitSHA:   `has(body.pullRequest) ? body.pullRequest.fromRef.latestCommit : body.changes[0].toHash`,
+		Branch:      `has(body.pullRequest) ? body.pullRequest.fromRef.displayId : body.changes[0].ref.displayId`,
+		EventSource: `header.get('X-Event-Key')[0]`,
+	}
+}
+
+func (b *bitbucketServer) PRPreviewRef(pr int) string {
+	return fmt.Sprintf("refs/pull-requests/%d/from", pr)
+}
@@ -0,0 +1,44 @@
+package scm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+type gitHub struct{}
+
+func (g *gitHub) Type() string { return "github" }
+
+func (g *gitHub) Match(url string) bool {
+	return strings.Contains(url, "github.com")
+}
+
+func (g *gitHub) WebhookPath() string { return "/github" }
+
+func (g *gitHub) EventInterceptor() *triggers.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		GitHub: &triggersv1alpha1.GitHubInterceptor{
+			SecretRef: &triggersv1alpha1.SecretRef{SecretKey: "webhook-secret"},
+		},
+	}
+}
+
+// CancelOverlays derives the cancellation fields from a GitHub push/
+// pull_request payload. A pull_request payload has no body.ref at all, so
+// the branch/commit-sha expressions branch on body.pull_request's presence
+// rather than evaluating body.ref against it directly.
+func (g *gitHub) CancelOverlays() triggers.CancelOverlays {
+	return triggers.CancelOverlays{
+		Filter:      `header.match('X-GitHub-Event', 'push') || header.match('X-GitHub-Event', 'pull_request')`,
+		CommitSHA:   `has(body.pull_request) ? body.pull_request.head.sha : body.head_commit.id`,
+		Branch:      `has(body.pull_request) ? body.pull_request.head.ref : body.ref.split('/')[2]`,
+		EventSource: `header.get('X-GitHub-Event')[0]`,
+	}
+}
+
+func (g *gitHub) PRPreviewRef(pr int) string {
+	return fmt.Sprintf("refs/pull/%d/head", pr)
+}
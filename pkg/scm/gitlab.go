@@ -0,0 +1,45 @@
+package scm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+type gitLab struct{}
+
+func (g *gitLab) Type() string { return "gitlab" }
+
+func (g *gitLab) Match(url string) bool {
+	return strings.Contains(url, "gitlab.com")
+}
+
+func (g *gitLab) WebhookPath() string { return "/gitlab" }
+
+func (g *gitLab) EventInterceptor() *triggers.EventInterceptor {
+	return &triggersv1alpha1.EventInterceptor{
+		GitLab: &triggersv1alpha1.GitLabInterceptor{
+			SecretRef: &triggersv1alpha1.SecretRef{SecretKey: "webhook-secret"},
+		},
+	}
+}
+
+// CancelOverlays derives the cancellation fields from a GitLab Push Hook/
+// Merge Request Hook payload. A Merge Request Hook payload has no
+// body.checkout_sha at all, so the branch/commit-sha expressions branch on
+// body.object_attributes's presence rather than evaluating body.ref against
+// it directly.
+func (g *gitLab) CancelOverlays() triggers.CancelOverlays {
+	return triggers.CancelOverlays{
+		Filter:      `header.match('X-Gitlab-Event', 'Push Hook') || header.match('X-Gitlab-Event', 'Merge Request Hook')`,
+		CommitSHA:   `has(body.object_attributes) ? body.object_attributes.last_commit.id : body.checkout_sha`,
+		Branch:      `has(body.object_attributes) ? body.object_attributes.source_branch : body.ref.split('/')[2]`,
+		EventSource: `header.get('X-Gitlab-Event')[0]`,
+	}
+}
+
+func (g *gitLab) PRPreviewRef(pr int) string {
+	return fmt.Sprintf("refs/merge-requests/%d/head", pr)
+}
@@ -0,0 +1,61 @@
+// Package scm abstracts over the source-control providers that can host a
+// GitOps repository or a service's source repository, so that the CLI can
+// detect which provider a URL belongs to and generate the right webhook and
+// EventListener wiring for it.
+package scm
+
+import "github.com/rhd-gitops-example/gitops-cli/pkg/pipelines/triggers"
+
+// Provider identifies a single source-control provider (GitHub, GitLab,
+// Bitbucket, etc.) and knows how to build the pieces of a generated manifest
+// that differ between providers.
+type Provider interface {
+	// Type is the short, stable name used in config and error messages,
+	// e.g. "github", "gitlab", "bitbucket-server".
+	Type() string
+
+	// Match reports whether url belongs to this provider.
+	Match(url string) bool
+
+	// WebhookPath is the EventListener path segment this provider's webhook
+	// requests arrive on.
+	WebhookPath() string
+
+	// EventInterceptor returns the Tekton Trigger interceptor for this
+	// provider's webhook payloads. The GitHub and GitLab interceptors verify
+	// the webhook secret; the others only filter events with CEL.
+	EventInterceptor() *triggers.EventInterceptor
+
+	// CancelOverlays returns the CEL filter and field expressions needed to
+	// derive the commit SHA, branch and event source that cancelling
+	// superseded PipelineRuns requires, evaluated against this provider's
+	// own webhook payload shape.
+	CancelOverlays() triggers.CancelOverlays
+
+	// PRPreviewRef returns the ref a preview environment should check out
+	// for the given pull/merge request number.
+	PRPreviewRef(pr int) string
+}
+
+// providers is the set of known providers, in the order Detect tries them.
+// The Bitbucket Server matcher only looks for a "/scm/" path segment, so it
+// is tried after the github.com, gitlab.com and bitbucket.org hostname
+// matchers.
+var providers = []Provider{
+	&gitHub{},
+	&gitLab{},
+	&bitbucketCloud{},
+	&bitbucketServer{},
+	&azureDevOps{},
+	&awsCodeCommit{},
+}
+
+// Detect returns the Provider that matches url, or nil if none do.
+func Detect(url string) Provider {
+	for _, p := range providers {
+		if p.Match(url) {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package scm
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/myproject/myservice.git", "github"},
+		{"https://gitlab.com/myproject/myservice.git", "gitlab"},
+		{"https://bitbucket.org/myproject/myservice.git", "bitbucket-cloud"},
+		{"https://bitbucket.example.com/scm/proj/myservice.git", "bitbucket-server"},
+		{"https://dev.azure.com/myorg/myproject/_git/myservice", "azure-devops"},
+		{"https://git-codecommit.us-east-1.amazonaws.com/v1/repos/myservice", "aws-codecommit"},
+		{"https://example.com/unknown/myservice.git", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(rt *testing.T) {
+			got := Detect(tt.url)
+			if tt.want == "" {
+				if got != nil {
+					rt.Fatalf("Detect(%q) = %q, want nil", tt.url, got.Type())
+				}
+				return
+			}
+			if got == nil {
+				rt.Fatalf("Detect(%q) = nil, want %q", tt.url, tt.want)
+			}
+			if got.Type() != tt.want {
+				rt.Fatalf("Detect(%q).Type() = %q, want %q", tt.url, got.Type(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPRPreviewRef(t *testing.T) {
+	tests := []struct {
+		provider Provider
+		pr       int
+		want     string
+	}{
+		{&gitHub{}, 42, "refs/pull/42/head"},
+		{&gitLab{}, 42, "refs/merge-requests/42/head"},
+		{&bitbucketCloud{}, 42, "refs/pull-requests/42/from"},
+		{&bitbucketServer{}, 42, "refs/pull-requests/42/from"},
+		{&azureDevOps{}, 42, "refs/pull/42/merge"},
+		{&awsCodeCommit{}, 42, "refs/heads/pr/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider.Type(), func(rt *testing.T) {
+			if got := tt.provider.PRPreviewRef(tt.pr); got != tt.want {
+				rt.Fatalf("PRPreviewRef(%d) = %q, want %q", tt.pr, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// unsupportedOutputError is returned by Format when asked for an output
+// format other than "text", "json" or "yaml".
+func unsupportedOutputError(output string) error {
+	return fmt.Errorf("unsupported version output format %q, must be one of: text, json, yaml", output)
+}
+
+// Format renders info in the given output format: "text" (the default,
+// human-readable), "json" or "yaml".
+func Format(info Info, output string) (string, error) {
+	switch output {
+	case "", "text":
+		return formatText(info), nil
+	case "json":
+		b, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal version info as json: %w", err)
+		}
+		return string(b) + "\n", nil
+	case "yaml":
+		b, err := yaml.Marshal(info)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal version info as yaml: %w", err)
+		}
+		return string(b), nil
+	default:
+		return "", unsupportedOutputError(output)
+	}
+}
+
+func formatText(info Info) string {
+	return fmt.Sprintf(
+		"gitops version %s\n  GitCommit:       %s\n  BuildDate:       %s\n  GoVersion:       %s\n  Platform:        %s\n  Tekton Pipelines: %s\n  Tekton Triggers:  %s\n  Argo CD:          %s\n",
+		info.Version, info.GitCommit, info.BuildDate, info.GoVersion, info.Platform,
+		info.TektonPipelines, info.TektonTriggers, info.ArgoCD,
+	)
+}
@@ -0,0 +1,67 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func testInfo() Info {
+	return Info{
+		Version:         "v1.2.3",
+		GitCommit:       "abcdef1",
+		BuildDate:       "2021-01-01T00:00:00Z",
+		GoVersion:       "go1.16",
+		Platform:        "linux/amd64",
+		TektonPipelines: TektonPipelinesVersion,
+		TektonTriggers:  TektonTriggersVersion,
+		ArgoCD:          ArgoCDVersion,
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		output       string
+		wantContains []string
+		wantErr      bool
+	}{
+		{"", []string{"gitops version v1.2.3", "GitCommit:       abcdef1"}, false},
+		{"text", []string{"gitops version v1.2.3", "Platform:        linux/amd64"}, false},
+		{"json", []string{`"version": "v1.2.3"`, `"gitCommit": "abcdef1"`}, false},
+		{"yaml", []string{"version: v1.2.3", "gitCommit: abcdef1"}, false},
+		{"xml", nil, true},
+	}
+
+	info := testInfo()
+	for _, tt := range tests {
+		t.Run(tt.output, func(rt *testing.T) {
+			got, err := Format(info, tt.output)
+			if tt.wantErr {
+				if err == nil {
+					rt.Fatalf("Format() did not return an error for output %q", tt.output)
+				}
+				return
+			}
+			if err != nil {
+				rt.Fatalf("Format() returned unexpected error: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					rt.Fatalf("Format(%q) = %q, want substring %q", tt.output, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	info := Get()
+	if info.GoVersion == "" {
+		t.Fatalf("Get().GoVersion is empty")
+	}
+	if info.Platform == "" {
+		t.Fatalf("Get().Platform is empty")
+	}
+	if info.TektonPipelines != TektonPipelinesVersion {
+		t.Fatalf("Get().TektonPipelines = %q, want %q", info.TektonPipelines, TektonPipelinesVersion)
+	}
+}
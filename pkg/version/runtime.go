@@ -0,0 +1,14 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func goVersion() string {
+	return runtime.Version()
+}
+
+func platform() string {
+	return fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,56 @@
+// Package version holds the build-time metadata the CLI embeds in its
+// binary, and the versions of the key controllers the CLI generates
+// manifests for. It is consumed by both the `version` command and, in the
+// future, by telemetry/user-agent headers on outgoing SCM API calls.
+package version
+
+// Build-time metadata, set via -ldflags by the Makefile. Version retains its
+// name and default so that existing -ldflags invocations that only set it
+// keep working.
+var (
+	Version   = "unreleased"
+	GitCommit string
+	BuildDate string
+)
+
+const (
+	// TektonPipelinesVersion is the version of Tekton Pipelines the
+	// generated manifests target.
+	TektonPipelinesVersion = "v0.22.0"
+
+	// TektonTriggersVersion is the version of Tekton Triggers the generated
+	// manifests target.
+	TektonTriggersVersion = "v0.13.0"
+
+	// ArgoCDVersion is the version of Argo CD the generated manifests
+	// target.
+	ArgoCDVersion = "v2.0.3"
+)
+
+// Info is the full set of version information the CLI can report about
+// itself and about the controllers it generates manifests for.
+type Info struct {
+	Version         string `json:"version" yaml:"version"`
+	GitCommit       string `json:"gitCommit" yaml:"gitCommit"`
+	BuildDate       string `json:"buildDate" yaml:"buildDate"`
+	GoVersion       string `json:"goVersion" yaml:"goVersion"`
+	Platform        string `json:"platform" yaml:"platform"`
+	TektonPipelines string `json:"tektonPipelines" yaml:"tektonPipelines"`
+	TektonTriggers  string `json:"tektonTriggers" yaml:"tektonTriggers"`
+	ArgoCD          string `json:"argoCD" yaml:"argoCD"`
+}
+
+// Get returns the Info for the running binary, combining the build-time
+// variables above with the Go runtime's own version/platform.
+func Get() Info {
+	return Info{
+		Version:         Version,
+		GitCommit:       GitCommit,
+		BuildDate:       BuildDate,
+		GoVersion:       goVersion(),
+		Platform:        platform(),
+		TektonPipelines: TektonPipelinesVersion,
+		TektonTriggers:  TektonTriggersVersion,
+		ArgoCD:          ArgoCDVersion,
+	}
+}